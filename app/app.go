@@ -20,6 +20,7 @@ type AppContext interface {
 	Logger(name string) *zap.SugaredLogger
 	Config() *Config
 	Contracts() []Contract
+	Metrics() *Metrics
 }
 
 type app struct {
@@ -27,6 +28,7 @@ type app struct {
 	logger    *zap.SugaredLogger
 	config    *Config
 	contracts []Contract
+	metrics   *Metrics
 }
 
 func NewApp(configPath string) App {
@@ -34,10 +36,13 @@ func NewApp(configPath string) App {
 	logger := zapLogger.Sugar()
 
 	logger.Debugf("Loading config from %s...", configPath)
-	config, err := LoadConfigJSON(configPath)
+	config, err := LoadConfig(configPath)
 	if err != nil {
 		logger.Fatalf("Failed to read config file: %v", err)
 	}
+	if len(config.Chains) > 0 {
+		logger.Infow("Per-chain settings are not overridable via environment variables; edit the config file to change them", "chains", len(config.Chains))
+	}
 
 	logger.Debug("Configuring contracts...")
 	contracts, err := LoadContracts(config, path.Dir(configPath))
@@ -49,6 +54,7 @@ func NewApp(configPath string) App {
 		logger:    logger,
 		config:    config,
 		contracts: contracts,
+		metrics:   NewMetrics(),
 	}
 }
 
@@ -68,39 +74,86 @@ func (a app) Contracts() []Contract {
 	return a.contracts
 }
 
+func (a app) Metrics() *Metrics {
+	return a.metrics
+}
+
 func (a app) Close() {
 	a.logger.Sync()
 }
 
+const defaultBoltCheckpointPath = "checkpoints.db"
+
+// newCheckpointStore picks where chain checkpoints are kept: the Postgres
+// checkpoint store if Postgres is configured as an output, otherwise a local
+// BoltDB file. The Postgres store holds checkpoints in memory only, so they
+// do not survive a restart; only the BoltDB file does.
+func (a *app) newCheckpointStore(db *Database) (CheckpointStore, error) {
+	if db != nil {
+		return NewPostgresCheckpointStore(db), nil
+	}
+
+	path := a.config.Checkpoints.BoltPath
+	if path == "" {
+		path = defaultBoltCheckpointPath
+	}
+	return NewBoltCheckpointStore(path)
+}
+
 func (a *app) Run() {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancelCause(context.Background())
 	go ShutdownHandler(cancel)
 
 	g, gctx := errgroup.WithContext(ctx)
 	a.ctx = gctx
 
-	outputs := NewOutputs()
-	if a.config.Outputs.Console == nil || !a.config.Outputs.Console.Disabled {
-		outputs.Add(NewLoggerOutput(a.logger))
+	outputs, err := NewConfiguredOutputs(a.config, a.logger, a.metrics)
+	if err != nil {
+		a.logger.Fatalw("Failed to configure outputs", "err", err)
 	}
 
-	handler := NewLogHandler(a.logger.Named("handler"), outputs)
-	chains := NewChains(a.config, a.logger.Named("chains"), a.contracts, handler)
-
+	var db *Database
 	if a.config.Outputs.Postgres != nil {
-		db := NewDatabase(a.logger.Named("db"))
+		db = NewDatabase(a.logger.Named("db"))
 		if err := db.Connect(ctx, a.config.Outputs.Postgres.URL); err != nil {
 			a.logger.Fatalw("Failed to connect Postgres", "url", a.config.Outputs.Postgres.URL)
 		}
-		defer db.Close(ctx)
 
+		outputs.Add(db)
+	}
+
+	checkpoints, err := a.newCheckpointStore(db)
+	if err != nil {
+		a.logger.Fatalw("Failed to open checkpoint store", "err", err)
+	}
+
+	handler := NewLogHandler(a.logger.Named("handler"), outputs, a.metrics)
+	chains := NewChains(a.config, a.logger.Named("chains"), a.contracts, handler, a.metrics, checkpoints)
+
+	if db != nil {
 		if err := db.MigrateSchema(ctx, chains); err != nil {
 			a.logger.Fatalw("Database.CreateSchemas failed", "err", err)
 		}
+	}
+
+	s := NewServer(a)
+	for _, chain := range chains {
+		if check, ok := chain.(Check); ok {
+			s.Register(check)
+		}
+		if source, ok := chain.(CheckpointSource); ok {
+			s.RegisterCheckpointSource(source)
+		}
+	}
 
-		outputs.Add(db)
+	for _, check := range outputs.Checks() {
+		s.RegisterReadyOnly(check)
 	}
 
+	g.Go(func() error {
+		return handler.Run(gctx)
+	})
+
 	for _, chain := range chains {
 		chain := chain
 
@@ -110,10 +163,35 @@ func (a *app) Run() {
 		})
 	}
 
-	s := NewServer(a)
 	g.Go(s.Run)
 
 	if err := g.Wait(); err != nil {
 		a.logger.Fatalf("Application error: %v", err)
 	}
+
+	a.logger.Infow("Subsystems stopped, draining queued events", "cause", context.Cause(gctx))
+
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), a.config.ShutdownTimeoutOrDefault())
+	defer cancelDrain()
+	if err := handler.Drain(drainCtx); err != nil {
+		a.logger.Errorw("Failed to drain all queued events", "err", err)
+	}
+
+	if db != nil {
+		closeCtx, cancelClose := context.WithTimeout(context.Background(), a.config.ShutdownTimeoutOrDefault())
+		defer cancelClose()
+		db.Close(closeCtx)
+	}
+
+	closeOutputsCtx, cancelCloseOutputs := context.WithTimeout(context.Background(), a.config.ShutdownTimeoutOrDefault())
+	defer cancelCloseOutputs()
+	if err := outputs.Close(closeOutputsCtx); err != nil {
+		a.logger.Errorw("Failed to close outputs", "err", err)
+	}
+
+	if closable, ok := checkpoints.(ClosableCheckpointStore); ok {
+		if err := closable.Close(); err != nil {
+			a.logger.Errorw("Failed to close checkpoint store", "err", err)
+		}
+	}
 }
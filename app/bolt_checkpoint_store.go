@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+)
+
+var checkpointsBucket = []byte("checkpoints")
+
+// boltCheckpointStore persists checkpoints in a local BoltDB file, used
+// when no Postgres output is configured to hold them instead.
+type boltCheckpointStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltCheckpointStore opens (creating if necessary) a BoltDB file at
+// path to use as a CheckpointStore.
+func NewBoltCheckpointStore(path string) (CheckpointStore, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint store %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(checkpointsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize checkpoint store %s: %w", path, err)
+	}
+
+	return &boltCheckpointStore{db: db}, nil
+}
+
+// LoadCheckpoint reads the last acknowledged block for chain, returning the
+// zero Checkpoint if none has been saved yet.
+func (s *boltCheckpointStore) LoadCheckpoint(ctx context.Context, chain string) (Checkpoint, error) {
+	var checkpoint Checkpoint
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(checkpointsBucket).Get([]byte(chain))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &checkpoint)
+	})
+
+	return checkpoint, err
+}
+
+// SaveCheckpoint persists checkpoint as the last acknowledged block for
+// chain.
+func (s *boltCheckpointStore) SaveCheckpoint(ctx context.Context, chain string, checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(checkpointsBucket).Put([]byte(chain), data)
+	})
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *boltCheckpointStore) Close() error {
+	return s.db.Close()
+}
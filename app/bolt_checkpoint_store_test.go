@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestBoltCheckpointStoreRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.db")
+
+	store, err := NewBoltCheckpointStore(path)
+	if err != nil {
+		t.Fatalf("NewBoltCheckpointStore returned error: %v", err)
+	}
+	defer store.(*boltCheckpointStore).Close()
+
+	ctx := context.Background()
+
+	checkpoint, err := store.LoadCheckpoint(ctx, "eth")
+	if err != nil {
+		t.Fatalf("LoadCheckpoint returned error: %v", err)
+	}
+	if checkpoint != (Checkpoint{}) {
+		t.Fatalf("expected zero Checkpoint for unseen chain, got %+v", checkpoint)
+	}
+
+	want := Checkpoint{BlockNumber: 42, BlockHash: "0xabc"}
+	if err := store.SaveCheckpoint(ctx, "eth", want); err != nil {
+		t.Fatalf("SaveCheckpoint returned error: %v", err)
+	}
+
+	got, err := store.LoadCheckpoint(ctx, "eth")
+	if err != nil {
+		t.Fatalf("LoadCheckpoint returned error: %v", err)
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestBoltCheckpointStoreImplementsClosableCheckpointStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.db")
+
+	store, err := NewBoltCheckpointStore(path)
+	if err != nil {
+		t.Fatalf("NewBoltCheckpointStore returned error: %v", err)
+	}
+
+	closable, ok := store.(ClosableCheckpointStore)
+	if !ok {
+		t.Fatal("expected boltCheckpointStore to implement ClosableCheckpointStore")
+	}
+	if err := closable.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
@@ -0,0 +1,325 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const defaultFreshnessWindow = 30 * time.Second
+
+const (
+	defaultConfirmations = uint64(12)
+	defaultMaxReorgDepth = uint64(100)
+)
+
+// Chain polls a single RPC endpoint for new blocks and forwards decoded
+// events to a LogHandler.
+type Chain interface {
+	Name() string
+	RunLoop(ctx context.Context)
+}
+
+// CheckpointSource exposes a Chain's last persisted checkpoint, e.g. for
+// reporting over the /api/checkpoints endpoint.
+type CheckpointSource interface {
+	Name() string
+	Checkpoint() Checkpoint
+}
+
+type chain struct {
+	name            string
+	rpcURL          string
+	pollInterval    time.Duration
+	freshnessWindow time.Duration
+	confirmations   uint64
+	maxReorgDepth   uint64
+	contracts       []Contract
+	logger          *zap.SugaredLogger
+	handler         *LogHandler
+	metrics         *Metrics
+	checkpoints     CheckpointStore
+
+	mu            sync.Mutex
+	lastBlockAt   time.Time
+	lastErr       error
+	checkpoint    Checkpoint
+	simulatedHead uint64
+}
+
+// NewChains builds one Chain per entry in config.Chains, restricted to the
+// contracts loaded for it. Each chain loads its initial Checkpoint from
+// checkpoints when its RunLoop starts.
+func NewChains(config *Config, logger *zap.SugaredLogger, contracts []Contract, handler *LogHandler, metrics *Metrics, checkpoints CheckpointStore) []Chain {
+	byChain := make(map[string][]Contract)
+	for _, c := range contracts {
+		byChain[c.Chain] = append(byChain[c.Chain], c)
+	}
+
+	freshnessWindow, err := time.ParseDuration(config.HTTP.FreshnessWindow)
+	if err != nil || freshnessWindow <= 0 {
+		freshnessWindow = defaultFreshnessWindow
+	}
+
+	chains := make([]Chain, 0, len(config.Chains))
+	for _, cfg := range config.Chains {
+		pollInterval, err := time.ParseDuration(cfg.PollInterval)
+		if err != nil || pollInterval <= 0 {
+			pollInterval = 5 * time.Second
+		}
+
+		confirmations := cfg.Confirmations
+		if confirmations == 0 {
+			confirmations = defaultConfirmations
+		}
+
+		maxReorgDepth := cfg.MaxReorgDepth
+		if maxReorgDepth == 0 {
+			maxReorgDepth = defaultMaxReorgDepth
+		}
+
+		chains = append(chains, &chain{
+			name:            cfg.Name,
+			rpcURL:          cfg.RPCURL,
+			pollInterval:    pollInterval,
+			freshnessWindow: freshnessWindow,
+			confirmations:   confirmations,
+			maxReorgDepth:   maxReorgDepth,
+			contracts:       byChain[cfg.Name],
+			logger:          logger.Named(cfg.Name),
+			handler:         handler,
+			metrics:         metrics,
+			checkpoints:     checkpoints,
+		})
+	}
+
+	return chains
+}
+
+func (c *chain) Name() string {
+	return c.name
+}
+
+// Checkpoint returns the last block this chain has acknowledged as
+// confirmed, for reporting over the /api/checkpoints endpoint.
+func (c *chain) Checkpoint() Checkpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkpoint
+}
+
+// RunLoop polls the chain's RPC endpoint for new blocks until ctx is
+// cancelled.
+func (c *chain) RunLoop(ctx context.Context) {
+	checkpoint, err := c.checkpoints.LoadCheckpoint(ctx, c.name)
+	if err != nil {
+		c.logger.Errorw("Failed to load checkpoint, resuming from head", "err", err)
+	} else {
+		c.mu.Lock()
+		c.checkpoint = checkpoint
+		c.mu.Unlock()
+	}
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Debug("Chain loop stopped")
+			return
+		case <-ticker.C:
+			c.poll(ctx)
+		}
+	}
+}
+
+const (
+	rpcMethodBlockNumber      = "eth_blockNumber"
+	rpcMethodGetBlockByNumber = "eth_getBlockByNumber"
+)
+
+func (c *chain) poll(ctx context.Context) {
+	// Fetching the current head and block hashes against c.rpcURL is out of
+	// scope here; fetchHead/fetchBlockHash are stubs standing in for that RPC
+	// client. No contract logs are fetched or decoded here; the only event
+	// poll forwards to c.handler.Handle is the reorg notice below.
+	start := time.Now()
+	head, err := c.fetchHead(ctx)
+	c.metrics.RecordRPCRequest(c.name, rpcMethodBlockNumber, rpcStatus(err), time.Since(start))
+	if err != nil {
+		c.mu.Lock()
+		c.lastErr = err
+		c.mu.Unlock()
+		c.logger.Errorw("Failed to fetch chain head", "err", err)
+		return
+	}
+
+	c.mu.Lock()
+	checkpoint := c.checkpoint
+	c.mu.Unlock()
+
+	if checkpoint.BlockNumber > 0 {
+		start := time.Now()
+		currentHash, err := c.fetchBlockHash(ctx, checkpoint.BlockNumber)
+		c.metrics.RecordRPCRequest(c.name, rpcMethodGetBlockByNumber, rpcStatus(err), time.Since(start))
+		if err != nil {
+			c.mu.Lock()
+			c.lastErr = err
+			c.mu.Unlock()
+			c.logger.Errorw("Failed to fetch block hash", "block", checkpoint.BlockNumber, "err", err)
+			return
+		}
+
+		if detectReorg(checkpoint, currentHash) {
+			target := rollbackTarget(checkpoint, c.maxReorgDepth)
+			c.logger.Warnw("Reorg detected, rolling back checkpoint", "chain", c.name, "from", checkpoint.BlockNumber, "to", target)
+			c.handler.Handle(ctx, Event{
+				Chain: c.name,
+				// There's no single contract log behind a reorg notice, so
+				// DedupeID is derived from the rollback itself rather than
+				// a tx hash/log index, keeping it unique per chain and per
+				// rollback instead of colliding on the zero value.
+				TxHash:      fmt.Sprintf("reorg:%s:%d->%d", c.name, checkpoint.BlockNumber, target),
+				BlockNumber: checkpoint.BlockNumber,
+				ObservedAt:  time.Now(),
+				Reorged:     true,
+			})
+			checkpoint = Checkpoint{BlockNumber: target}
+		}
+	}
+
+	advanced := false
+	if target, ok := nextConfirmedBlock(head, c.confirmations); ok && target > checkpoint.BlockNumber {
+		start := time.Now()
+		hash, err := c.fetchBlockHash(ctx, target)
+		c.metrics.RecordRPCRequest(c.name, rpcMethodGetBlockByNumber, rpcStatus(err), time.Since(start))
+		if err != nil {
+			c.mu.Lock()
+			c.lastErr = err
+			c.mu.Unlock()
+			c.logger.Errorw("Failed to fetch block hash", "block", target, "err", err)
+			return
+		}
+
+		checkpoint = Checkpoint{BlockNumber: target, BlockHash: hash}
+		if err := c.checkpoints.SaveCheckpoint(ctx, c.name, checkpoint); err != nil {
+			c.logger.Errorw("Failed to save checkpoint", "err", err)
+		}
+		advanced = true
+	}
+
+	c.metrics.SetHeadLag(c.name, headLag(head, checkpoint.BlockNumber))
+
+	c.mu.Lock()
+	c.checkpoint = checkpoint
+	// lastBlockAt only moves when the confirmed checkpoint actually
+	// advances, not on every poll that merely didn't hard-error, so
+	// CheckReady's freshness check reflects real progress against head
+	// instead of "the chain is still polling".
+	if advanced {
+		c.lastBlockAt = time.Now()
+	}
+	c.lastErr = nil
+	c.mu.Unlock()
+}
+
+func rpcStatus(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// headLag returns how many blocks checkpoint is behind head, clamped to 0
+// when checkpoint is at or ahead of head (e.g. right after a restart, where
+// a persisted checkpoint can momentarily read ahead of a freshly-queried
+// RPC head) so the gauge never reports the underflowed result of a uint64
+// subtraction gone negative.
+func headLag(head, checkpoint uint64) float64 {
+	if checkpoint >= head {
+		return 0
+	}
+	return float64(head - checkpoint)
+}
+
+// fetchHead is a stub standing in for an eth_blockNumber RPC call against
+// c.rpcURL; a real implementation would return the chain's current head.
+// Unlike c.checkpoint, the simulated head advances on every call regardless
+// of how far the monitor has confirmed, mirroring a real RPC head that
+// keeps growing whether or not the monitor is keeping up with it.
+func (c *chain) fetchHead(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.simulatedHead++
+	return c.simulatedHead, nil
+}
+
+// fetchBlockHash is a stub standing in for an eth_getBlockByNumber RPC call
+// against c.rpcURL; a real implementation would return the hash of the
+// block at blockNumber as currently seen by the node.
+func (c *chain) fetchBlockHash(ctx context.Context, blockNumber uint64) (string, error) {
+	return "", nil
+}
+
+// nextConfirmedBlock returns the highest block number that has reached
+// confirmations depth below head, and whether head is deep enough to have
+// any confirmed block at all.
+func nextConfirmedBlock(head uint64, confirmations uint64) (target uint64, ok bool) {
+	if head < confirmations {
+		return 0, false
+	}
+	return head - confirmations, true
+}
+
+// detectReorg reports whether the chain's current view of
+// checkpoint.BlockNumber's hash no longer matches what was recorded when
+// the checkpoint was saved, meaning blocks at or above it were reorged out.
+func detectReorg(checkpoint Checkpoint, currentHash string) bool {
+	if checkpoint.BlockHash == "" {
+		return false
+	}
+	return currentHash != checkpoint.BlockHash
+}
+
+// rollbackTarget returns the block number to resume from after a reorg,
+// stepping back maxReorgDepth blocks from checkpoint so previously emitted
+// events in the affected range can be re-processed.
+func rollbackTarget(checkpoint Checkpoint, maxReorgDepth uint64) uint64 {
+	if checkpoint.BlockNumber <= maxReorgDepth {
+		return 0
+	}
+	return checkpoint.BlockNumber - maxReorgDepth
+}
+
+// CheckLive reports a hard failure of the chain's RPC connection.
+func (c *chain) CheckLive(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastErr
+}
+
+// CheckReady reports whether the confirmed checkpoint has advanced within
+// the freshness window, i.e. it is not stuck behind the RPC head. Unlike
+// CheckLive, a chain whose polls keep succeeding but whose checkpoint never
+// catches up (e.g. confirmations misconfigured, or a stuck backlog) is
+// reported not-ready rather than live-forever, since lastBlockAt only moves
+// when poll actually advances the checkpoint.
+func (c *chain) CheckReady(ctx context.Context) error {
+	c.mu.Lock()
+	lastBlockAt := c.lastBlockAt
+	c.mu.Unlock()
+
+	if lastBlockAt.IsZero() {
+		return fmt.Errorf("chain %s has not processed a block yet", c.name)
+	}
+
+	if lag := time.Since(lastBlockAt); lag > c.freshnessWindow {
+		return fmt.Errorf("chain %s is stale: last block %s ago exceeds freshness window %s", c.name, lag, c.freshnessWindow)
+	}
+
+	return nil
+}
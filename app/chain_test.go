@@ -0,0 +1,304 @@
+package app
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// fakeCheckpointStore is an in-memory CheckpointStore for driving poll()
+// end-to-end without a real database.
+type fakeCheckpointStore struct {
+	saved []Checkpoint
+}
+
+func (s *fakeCheckpointStore) LoadCheckpoint(ctx context.Context, chain string) (Checkpoint, error) {
+	return Checkpoint{}, nil
+}
+
+func (s *fakeCheckpointStore) SaveCheckpoint(ctx context.Context, chain string, checkpoint Checkpoint) error {
+	s.saved = append(s.saved, checkpoint)
+	return nil
+}
+
+func TestNextConfirmedBlock(t *testing.T) {
+	cases := []struct {
+		head, confirmations uint64
+		wantTarget          uint64
+		wantOK              bool
+	}{
+		{head: 100, confirmations: 12, wantTarget: 88, wantOK: true},
+		{head: 12, confirmations: 12, wantTarget: 0, wantOK: true},
+		{head: 11, confirmations: 12, wantOK: false},
+	}
+
+	for _, c := range cases {
+		target, ok := nextConfirmedBlock(c.head, c.confirmations)
+		if ok != c.wantOK || (ok && target != c.wantTarget) {
+			t.Errorf("nextConfirmedBlock(%d, %d) = (%d, %v), want (%d, %v)", c.head, c.confirmations, target, ok, c.wantTarget, c.wantOK)
+		}
+	}
+}
+
+func TestDetectReorg(t *testing.T) {
+	cases := []struct {
+		name        string
+		checkpoint  Checkpoint
+		currentHash string
+		want        bool
+	}{
+		{name: "unchanged", checkpoint: Checkpoint{BlockNumber: 10, BlockHash: "0xabc"}, currentHash: "0xabc", want: false},
+		{name: "reorged", checkpoint: Checkpoint{BlockNumber: 10, BlockHash: "0xabc"}, currentHash: "0xdef", want: true},
+		{name: "no prior hash", checkpoint: Checkpoint{BlockNumber: 10}, currentHash: "0xdef", want: false},
+	}
+
+	for _, c := range cases {
+		if got := detectReorg(c.checkpoint, c.currentHash); got != c.want {
+			t.Errorf("%s: detectReorg() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestHeadLag(t *testing.T) {
+	cases := []struct {
+		name             string
+		head, checkpoint uint64
+		want             float64
+	}{
+		{name: "behind", head: 100, checkpoint: 88, want: 12},
+		{name: "caught up", head: 100, checkpoint: 100, want: 0},
+		{name: "checkpoint ahead of head", head: 1, checkpoint: 100, want: 0},
+	}
+
+	for _, c := range cases {
+		if got := headLag(c.head, c.checkpoint); got != c.want {
+			t.Errorf("%s: headLag(%d, %d) = %v, want %v", c.name, c.head, c.checkpoint, got, c.want)
+		}
+	}
+}
+
+func TestRollbackTarget(t *testing.T) {
+	cases := []struct {
+		checkpoint    Checkpoint
+		maxReorgDepth uint64
+		want          uint64
+	}{
+		{checkpoint: Checkpoint{BlockNumber: 200}, maxReorgDepth: 100, want: 100},
+		{checkpoint: Checkpoint{BlockNumber: 50}, maxReorgDepth: 100, want: 0},
+	}
+
+	for _, c := range cases {
+		if got := rollbackTarget(c.checkpoint, c.maxReorgDepth); got != c.want {
+			t.Errorf("rollbackTarget(%+v, %d) = %d, want %d", c.checkpoint, c.maxReorgDepth, got, c.want)
+		}
+	}
+}
+
+// TestChainPollAdvancesCheckpointAcrossCalls drives poll() directly (rather
+// than just its pure helpers) to guard against fetchHead regressing into a
+// stub that derives the head from c.checkpoint itself, which would pin
+// nextConfirmedBlock's target at checkpoint.BlockNumber forever and wedge
+// the checkpoint at its initial value on every call.
+func TestChainPollAdvancesCheckpointAcrossCalls(t *testing.T) {
+	store := &fakeCheckpointStore{}
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	handler := NewLogHandler(zap.NewNop().Sugar(), outputs, NewMetrics())
+
+	c := &chain{
+		name:          "eth",
+		confirmations: 1,
+		maxReorgDepth: defaultMaxReorgDepth,
+		logger:        zap.NewNop().Sugar(),
+		handler:       handler,
+		metrics:       NewMetrics(),
+		checkpoints:   store,
+	}
+
+	ctx := context.Background()
+	var seen []uint64
+	for i := 0; i < 3; i++ {
+		c.poll(ctx)
+		seen = append(seen, c.Checkpoint().BlockNumber)
+	}
+
+	if seen[0] >= seen[len(seen)-1] {
+		t.Fatalf("expected checkpoint to advance across polls, got %v", seen)
+	}
+	if len(store.saved) == 0 {
+		t.Fatal("expected at least one checkpoint to be persisted")
+	}
+}
+
+// TestChainCheckReadyFailsWhenCheckpointNeverAdvances guards against
+// CheckReady reporting ready forever as long as polls merely don't
+// hard-error, even though the confirmed checkpoint never catches up to
+// head (e.g. confirmations misconfigured so no block is ever confirmed).
+// CheckLive should stay happy throughout, since the RPC calls themselves
+// keep succeeding.
+func TestChainCheckReadyFailsWhenCheckpointNeverAdvances(t *testing.T) {
+	store := &fakeCheckpointStore{}
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	handler := NewLogHandler(zap.NewNop().Sugar(), outputs, NewMetrics())
+
+	c := &chain{
+		name: "eth",
+		// No amount of polling ever reaches this many confirmations, so
+		// the checkpoint can never advance past its zero value.
+		confirmations:   1_000_000,
+		maxReorgDepth:   defaultMaxReorgDepth,
+		freshnessWindow: defaultFreshnessWindow,
+		logger:          zap.NewNop().Sugar(),
+		handler:         handler,
+		metrics:         NewMetrics(),
+		checkpoints:     store,
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		c.poll(ctx)
+	}
+
+	if err := c.CheckLive(ctx); err != nil {
+		t.Fatalf("expected CheckLive to be nil since polls keep succeeding, got: %v", err)
+	}
+	if err := c.CheckReady(ctx); err == nil {
+		t.Fatal("expected CheckReady to report not-ready when the checkpoint never advances")
+	}
+}
+
+// TestChainPollClampsHeadLagWhenCheckpointAheadOfHead guards against the
+// chain_head_lag_blocks gauge underflowing to ~1.8e19 whenever a persisted
+// checkpoint reads ahead of a freshly-queried RPC head, which is exactly
+// the state a restart can land in and precisely when operators are
+// watching this gauge to detect a stuck chain.
+func TestChainPollClampsHeadLagWhenCheckpointAheadOfHead(t *testing.T) {
+	store := &fakeCheckpointStore{}
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	metrics := NewMetrics()
+	handler := NewLogHandler(zap.NewNop().Sugar(), outputs, metrics)
+
+	c := &chain{
+		name:          "eth",
+		confirmations: 1,
+		maxReorgDepth: defaultMaxReorgDepth,
+		logger:        zap.NewNop().Sugar(),
+		handler:       handler,
+		metrics:       metrics,
+		checkpoints:   store,
+		checkpoint:    Checkpoint{BlockNumber: 100},
+	}
+
+	c.poll(context.Background())
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, req)
+
+	want := `chain_head_lag_blocks{chain="eth"} 0`
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected /metrics output to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
+
+// capturingOutput records every Event it's asked to Publish, for tests that
+// need to inspect what a Chain actually emitted.
+type capturingOutput struct {
+	events []Event
+}
+
+func (o *capturingOutput) Name() string { return "capturing" }
+
+func (o *capturingOutput) Publish(ctx context.Context, event Event) error {
+	o.events = append(o.events, event)
+	return nil
+}
+
+func (o *capturingOutput) HealthCheck(ctx context.Context) error { return nil }
+
+// TestChainPollReorgEventsHaveUniqueDedupeID guards against every reorg
+// notice sharing the zero-value TxHash/LogIndex, which would make
+// Event.DedupeID() collide across unrelated reorgs (even on different
+// chains) and cause a downstream dedup window (e.g. NATS JetStream) to drop
+// the second reorg as a spurious duplicate of the first.
+func TestChainPollReorgEventsHaveUniqueDedupeID(t *testing.T) {
+	capture := &capturingOutput{}
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	outputs.Add(capture)
+	handler := NewLogHandler(zap.NewNop().Sugar(), outputs, NewMetrics())
+
+	c := &chain{
+		name:          "eth",
+		confirmations: 1,
+		maxReorgDepth: defaultMaxReorgDepth,
+		logger:        zap.NewNop().Sugar(),
+		handler:       handler,
+		metrics:       NewMetrics(),
+		checkpoints:   &fakeCheckpointStore{},
+	}
+
+	ctx := context.Background()
+
+	c.checkpoint = Checkpoint{BlockNumber: 10, BlockHash: "0xabc"}
+	c.poll(ctx)
+
+	c.checkpoint = Checkpoint{BlockNumber: 50, BlockHash: "0xdef"}
+	c.poll(ctx)
+
+	if err := handler.Drain(ctx); err != nil {
+		t.Fatalf("Drain returned error: %v", err)
+	}
+
+	var reorgs []Event
+	for _, e := range capture.events {
+		if e.Reorged {
+			reorgs = append(reorgs, e)
+		}
+	}
+	if len(reorgs) != 2 {
+		t.Fatalf("expected 2 reorg events, got %d: %+v", len(reorgs), capture.events)
+	}
+	if reorgs[0].DedupeID() == reorgs[1].DedupeID() {
+		t.Errorf("expected distinct DedupeID per reorg, both got %q", reorgs[0].DedupeID())
+	}
+	if reorgs[0].DedupeID() == ":0" {
+		t.Errorf("reorg event kept the zero-value DedupeID %q", reorgs[0].DedupeID())
+	}
+}
+
+// TestChainPollRecordsBlockHashRPCMetrics guards against fetchBlockHash call
+// sites silently going unmetered, which would under-count RPC requests and
+// hide latency/errors for exactly the calls most likely to expose a
+// stuck/misbehaving RPC endpoint.
+func TestChainPollRecordsBlockHashRPCMetrics(t *testing.T) {
+	store := &fakeCheckpointStore{}
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	metrics := NewMetrics()
+	handler := NewLogHandler(zap.NewNop().Sugar(), outputs, metrics)
+
+	c := &chain{
+		name:          "eth",
+		confirmations: 1,
+		maxReorgDepth: defaultMaxReorgDepth,
+		logger:        zap.NewNop().Sugar(),
+		handler:       handler,
+		metrics:       metrics,
+		checkpoints:   store,
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		c.poll(ctx)
+	}
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, req)
+
+	want := `rpc_requests_total{chain="eth",method="eth_getBlockByNumber",status="success"}`
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected /metrics output to contain %q, got:\n%s", want, rec.Body.String())
+	}
+}
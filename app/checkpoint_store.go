@@ -0,0 +1,25 @@
+package app
+
+import "context"
+
+// Checkpoint is the last block a Chain has acknowledged as confirmed.
+type Checkpoint struct {
+	BlockNumber uint64
+	BlockHash   string
+}
+
+// CheckpointStore holds each chain's Checkpoint so the monitor can resume
+// from there instead of the RPC head. boltCheckpointStore keeps it across a
+// restart; postgresCheckpointStore keeps it in memory only and does not.
+type CheckpointStore interface {
+	LoadCheckpoint(ctx context.Context, chain string) (Checkpoint, error)
+	SaveCheckpoint(ctx context.Context, chain string, checkpoint Checkpoint) error
+}
+
+// ClosableCheckpointStore is optionally implemented by a CheckpointStore
+// holding a resource that needs releasing on shutdown, e.g. boltCheckpointStore's
+// open file handle. Backends without one, like postgresCheckpointStore,
+// don't implement it.
+type ClosableCheckpointStore interface {
+	Close() error
+}
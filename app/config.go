@@ -0,0 +1,199 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config is the root configuration loaded from the JSON file passed to
+// NewApp, optionally overridden by environment variables in LoadConfig.
+type Config struct {
+	HTTP HTTPConfig `json:"http"`
+
+	// Chains is JSON-file-only: envconfig has no way to address one element
+	// of a slice of structs by name, so per-chain RPC URL, poll interval,
+	// confirmations and max reorg depth cannot be overridden via the
+	// environment the way every other field here can. LoadConfig's doc
+	// comment states the same limit.
+	Chains          []ChainConfig     `json:"chains"`
+	Outputs         OutputsConfig     `json:"outputs"`
+	Checkpoints     CheckpointsConfig `json:"checkpoints"`
+	ShutdownTimeout string            `json:"shutdown_timeout" split_words:"true"`
+}
+
+// ShutdownTimeoutOrDefault returns how long subsystems are given to drain
+// on shutdown, falling back to defaultShutdownTimeout if unset or invalid.
+func (c *Config) ShutdownTimeoutOrDefault() time.Duration {
+	timeout, err := time.ParseDuration(c.ShutdownTimeout)
+	if err != nil || timeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return timeout
+}
+
+const defaultShutdownTimeout = 10 * time.Second
+
+// HTTPConfig controls the app's HTTP server.
+type HTTPConfig struct {
+	ListenAddr      string `json:"listen_addr" split_words:"true"`
+	EnablePprof     bool   `json:"enable_pprof" split_words:"true"`
+	FreshnessWindow string `json:"freshness_window" split_words:"true"`
+}
+
+// ChainConfig describes a single chain to monitor.
+type ChainConfig struct {
+	Name          string   `json:"name"`
+	RPCURL        string   `json:"rpc_url"`
+	PollInterval  string   `json:"poll_interval"`
+	Contracts     []string `json:"contracts"`
+	Confirmations uint64   `json:"confirmations"`
+	MaxReorgDepth uint64   `json:"max_reorg_depth"`
+}
+
+// CheckpointsConfig controls where chain checkpoints are persisted when no
+// Postgres output is configured to hold them instead.
+type CheckpointsConfig struct {
+	BoltPath string `json:"bolt_path" split_words:"true"`
+}
+
+// OutputsConfig lists the sinks events are published to. Adding a new sink
+// only requires a field here plus a case in NewConfiguredOutputs — app.Run
+// itself stays untouched (Postgres is the one exception, since it also owns
+// schema migration for the chains).
+type OutputsConfig struct {
+	Console  *ConsoleOutputConfig  `json:"console"`
+	Postgres *PostgresOutputConfig `json:"postgres"`
+	NATS     *NATSOutputConfig     `json:"nats"`
+	Kafka    *KafkaOutputConfig    `json:"kafka"`
+}
+
+// ConsoleOutputConfig configures the built-in logger output.
+type ConsoleOutputConfig struct {
+	Disabled bool `json:"disabled"`
+}
+
+// PostgresOutputConfig configures the Postgres output.
+type PostgresOutputConfig struct {
+	URL string `json:"url" envconfig:"URL"`
+}
+
+// NATSOutputConfig configures the NATS JetStream output.
+type NATSOutputConfig struct {
+	URL           string `json:"url" envconfig:"URL"`
+	SubjectPrefix string `json:"subject_prefix" split_words:"true"`
+}
+
+// KafkaOutputConfig configures the Kafka output.
+type KafkaOutputConfig struct {
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+}
+
+// LoadConfigJSON reads and parses the config file at configPath.
+func LoadConfigJSON(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// LoadConfig reads Config from the JSON file at configPath, then overlays
+// any matching environment variables (e.g. OUTPUTS_POSTGRES_URL), which take
+// precedence over the file. This lets deployments keep secrets like the
+// Postgres URL out of the checked-in JSON and supply them via the
+// environment, or a .env file picked up by the godotenv/autoload import in
+// app.go. Every scalar field of Config is overridable this way; Chains is
+// not, since envconfig has no way to address one element of a slice of
+// structs by name, so per-chain settings stay file-only.
+func LoadConfig(configPath string) (*Config, error) {
+	config, err := LoadConfigJSON(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var envOverrides Config
+	if err := envconfig.Process("", &envOverrides); err != nil {
+		return nil, fmt.Errorf("failed to load config from environment: %w", err)
+	}
+
+	mergeEnvOverrides(config, &envOverrides)
+
+	return config, nil
+}
+
+// mergeEnvOverrides copies every non-zero scalar field of env onto config.
+// Chains is deliberately left untouched: it is a slice of structs, and
+// envconfig has no mechanism to address one element of it by name, so
+// per-chain settings can only come from the JSON file.
+func mergeEnvOverrides(config *Config, env *Config) {
+	if env.HTTP.ListenAddr != "" {
+		config.HTTP.ListenAddr = env.HTTP.ListenAddr
+	}
+	if env.HTTP.EnablePprof {
+		config.HTTP.EnablePprof = true
+	}
+	if env.HTTP.FreshnessWindow != "" {
+		config.HTTP.FreshnessWindow = env.HTTP.FreshnessWindow
+	}
+	if env.ShutdownTimeout != "" {
+		config.ShutdownTimeout = env.ShutdownTimeout
+	}
+
+	if env.Checkpoints.BoltPath != "" {
+		config.Checkpoints.BoltPath = env.Checkpoints.BoltPath
+	}
+
+	if env.Outputs.Console != nil && env.Outputs.Console.Disabled {
+		if config.Outputs.Console == nil {
+			config.Outputs.Console = &ConsoleOutputConfig{}
+		}
+		config.Outputs.Console.Disabled = true
+	}
+
+	if env.Outputs.Postgres != nil && env.Outputs.Postgres.URL != "" {
+		if config.Outputs.Postgres == nil {
+			config.Outputs.Postgres = &PostgresOutputConfig{}
+		}
+		config.Outputs.Postgres.URL = env.Outputs.Postgres.URL
+	}
+
+	// env.Outputs.NATS/Kafka are never nil here: envconfig allocates every
+	// nested struct pointer while walking Config regardless of whether any
+	// matching env var is set. Gate on an actual sub-field instead, the same
+	// way the Console/Postgres blocks above do, so an unconfigured NATS/Kafka
+	// output isn't accidentally turned on by a zero-value *NATSOutputConfig.
+	if env.Outputs.NATS != nil && (env.Outputs.NATS.URL != "" || env.Outputs.NATS.SubjectPrefix != "") {
+		if config.Outputs.NATS == nil {
+			config.Outputs.NATS = &NATSOutputConfig{}
+		}
+		if env.Outputs.NATS.URL != "" {
+			config.Outputs.NATS.URL = env.Outputs.NATS.URL
+		}
+		if env.Outputs.NATS.SubjectPrefix != "" {
+			config.Outputs.NATS.SubjectPrefix = env.Outputs.NATS.SubjectPrefix
+		}
+	}
+
+	if env.Outputs.Kafka != nil && (len(env.Outputs.Kafka.Brokers) > 0 || env.Outputs.Kafka.Topic != "") {
+		if config.Outputs.Kafka == nil {
+			config.Outputs.Kafka = &KafkaOutputConfig{}
+		}
+		if len(env.Outputs.Kafka.Brokers) > 0 {
+			config.Outputs.Kafka.Brokers = env.Outputs.Kafka.Brokers
+		}
+		if env.Outputs.Kafka.Topic != "" {
+			config.Outputs.Kafka.Topic = env.Outputs.Kafka.Topic
+		}
+	}
+}
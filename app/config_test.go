@@ -0,0 +1,109 @@
+package app
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadConfigEnvOverridesJSON(t *testing.T) {
+	t.Setenv("OUTPUTS_POSTGRES_URL", "postgres://env-host/db")
+	t.Setenv("HTTP_LISTEN_ADDR", ":9090")
+
+	dir := t.TempDir()
+	configPath := dir + "/config.json"
+	writeFile(t, configPath, `{
+		"http": {"listen_addr": ":8080"},
+		"outputs": {"postgres": {"url": "postgres://file-host/db"}}
+	}`)
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	if config.HTTP.ListenAddr != ":9090" {
+		t.Errorf("expected env HTTP.ListenAddr to win, got %q", config.HTTP.ListenAddr)
+	}
+	if config.Outputs.Postgres.URL != "postgres://env-host/db" {
+		t.Errorf("expected env Postgres URL to win, got %q", config.Outputs.Postgres.URL)
+	}
+}
+
+func TestLoadConfigEnvOverridesNATSKafkaAndCheckpoints(t *testing.T) {
+	t.Setenv("OUTPUTS_NATS_URL", "nats://env-host:4222")
+	t.Setenv("OUTPUTS_NATS_SUBJECT_PREFIX", "env-contracts")
+	t.Setenv("OUTPUTS_KAFKA_BROKERS", "env-broker:9092")
+	t.Setenv("OUTPUTS_KAFKA_TOPIC", "env-events")
+	t.Setenv("CHECKPOINTS_BOLT_PATH", "/env/checkpoints.db")
+
+	dir := t.TempDir()
+	configPath := dir + "/config.json"
+	writeFile(t, configPath, `{
+		"outputs": {
+			"nats": {"url": "nats://file-host:4222", "subject_prefix": "file-contracts"},
+			"kafka": {"brokers": ["file-broker:9092"], "topic": "file-events"}
+		},
+		"checkpoints": {"bolt_path": "/file/checkpoints.db"}
+	}`)
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	if config.Outputs.NATS.URL != "nats://env-host:4222" {
+		t.Errorf("expected env NATS URL to win, got %q", config.Outputs.NATS.URL)
+	}
+	if config.Outputs.NATS.SubjectPrefix != "env-contracts" {
+		t.Errorf("expected env NATS subject prefix to win, got %q", config.Outputs.NATS.SubjectPrefix)
+	}
+	if len(config.Outputs.Kafka.Brokers) != 1 || config.Outputs.Kafka.Brokers[0] != "env-broker:9092" {
+		t.Errorf("expected env Kafka brokers to win, got %v", config.Outputs.Kafka.Brokers)
+	}
+	if config.Outputs.Kafka.Topic != "env-events" {
+		t.Errorf("expected env Kafka topic to win, got %q", config.Outputs.Kafka.Topic)
+	}
+	if config.Checkpoints.BoltPath != "/env/checkpoints.db" {
+		t.Errorf("expected env checkpoints bolt path to win, got %q", config.Checkpoints.BoltPath)
+	}
+}
+
+func TestLoadConfigLeavesUnconfiguredNATSAndKafkaNil(t *testing.T) {
+	dir := t.TempDir()
+	configPath := dir + "/config.json"
+	writeFile(t, configPath, `{}`)
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	if config.Outputs.NATS != nil {
+		t.Errorf("expected NATS output to stay unconfigured, got %+v", config.Outputs.NATS)
+	}
+	if config.Outputs.Kafka != nil {
+		t.Errorf("expected Kafka output to stay unconfigured, got %+v", config.Outputs.Kafka)
+	}
+}
+
+func TestLoadConfigFallsBackToFileWithoutEnv(t *testing.T) {
+	dir := t.TempDir()
+	configPath := dir + "/config.json"
+	writeFile(t, configPath, `{"outputs": {"postgres": {"url": "postgres://file-host/db"}}}`)
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	if config.Outputs.Postgres.URL != "postgres://file-host/db" {
+		t.Errorf("expected file Postgres URL to be kept, got %q", config.Outputs.Postgres.URL)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import "path/filepath"
+
+// Contract is a single contract instance to watch on a given chain.
+type Contract struct {
+	Name    string
+	Chain   string
+	ABIPath string
+}
+
+// LoadContracts resolves the contracts referenced by config.Chains, locating
+// their ABI files relative to baseDir (the directory holding the config
+// file).
+func LoadContracts(config *Config, baseDir string) ([]Contract, error) {
+	var contracts []Contract
+
+	for _, chain := range config.Chains {
+		for _, name := range chain.Contracts {
+			contracts = append(contracts, Contract{
+				Name:    name,
+				Chain:   chain.Name,
+				ABIPath: filepath.Join(baseDir, "abi", name+".json"),
+			})
+		}
+	}
+
+	return contracts, nil
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// Database is the Postgres Output. It holds no connection: every method
+// below only logs or tracks a connected flag, and nothing is sent to
+// Postgres.
+type Database struct {
+	logger    *zap.SugaredLogger
+	connected bool
+}
+
+// NewDatabase creates an unconnected Database.
+func NewDatabase(logger *zap.SugaredLogger) *Database {
+	return &Database{logger: logger}
+}
+
+// Connect logs url and marks the Database connected; it opens no
+// connection.
+func (d *Database) Connect(ctx context.Context, url string) error {
+	d.logger.Debugw("Connecting to Postgres", "url", url)
+	d.connected = true
+	return nil
+}
+
+// Name identifies this Output in health/ready responses.
+func (d *Database) Name() string {
+	return "postgres"
+}
+
+// HealthCheck reports an error until Connect has been called; it does not
+// ping Postgres.
+func (d *Database) HealthCheck(ctx context.Context) error {
+	if !d.connected {
+		return errors.New("postgres: not connected")
+	}
+	// A real ping would run "SELECT 1" against the pool here.
+	return nil
+}
+
+// Close logs the shutdown; there is no connection to release.
+func (d *Database) Close(ctx context.Context) {
+	d.logger.Debug("Closing Postgres connection")
+}
+
+// MigrateSchema logs each chain's name; it creates no tables.
+func (d *Database) MigrateSchema(ctx context.Context, chains []Chain) error {
+	for _, c := range chains {
+		d.logger.Debugw("Migrating schema", "chain", c.Name())
+	}
+	return nil
+}
+
+// Publish logs event's chain and tx hash; it stores nothing.
+func (d *Database) Publish(ctx context.Context, event Event) error {
+	d.logger.Debugw("Storing event", "chain", event.Chain, "txHash", event.TxHash)
+	return nil
+}
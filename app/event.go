@@ -0,0 +1,37 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+// Event is a decoded contract log emitted by a Chain and delivered to the
+// configured Outputs.
+type Event struct {
+	Chain       string                 `json:"chain"`
+	Contract    string                 `json:"contract"`
+	Name        string                 `json:"name"`
+	TxHash      string                 `json:"tx_hash"`
+	LogIndex    uint                   `json:"log_index"`
+	BlockNumber uint64                 `json:"block_number"`
+	Args        map[string]interface{} `json:"args,omitempty"`
+
+	// ObservedAt is when the Chain first saw this event, used to measure
+	// end-to-end processing latency. The zero value means "unknown", e.g.
+	// for events constructed directly in tests.
+	ObservedAt time.Time `json:"observed_at"`
+
+	// Reorged marks the notice a Chain emits when a reorg rolls its
+	// checkpoint back past a previously acknowledged block, so downstream
+	// sinks can compensate. One notice is emitted per rollback, carrying the
+	// chain and the old checkpoint's block number; the events in the
+	// rolled-back range are not re-emitted.
+	Reorged bool `json:"reorged,omitempty"`
+}
+
+// DedupeID is a stable identifier for this event, suitable for use as a
+// downstream dedup key (e.g. NATS's Msg-Id header or a Kafka header) so a
+// consumer restart cannot double-process it.
+func (e Event) DedupeID() string {
+	return fmt.Sprintf("%s:%d", e.TxHash, e.LogIndex)
+}
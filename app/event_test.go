@@ -0,0 +1,34 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEventMarshalsSnakeCaseFields(t *testing.T) {
+	event := Event{
+		Chain:       "eth",
+		Contract:    "token",
+		Name:        "Transfer",
+		TxHash:      "0xabc",
+		LogIndex:    1,
+		BlockNumber: 42,
+		Reorged:     true,
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	for _, key := range []string{"chain", "contract", "name", "tx_hash", "log_index", "block_number", "reorged"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("expected JSON output to contain %q, got: %s", key, data)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kafka "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// kafkaOutput publishes events to a Kafka topic, keyed by contract so every
+// event for a given contract lands on the same partition.
+type kafkaOutput struct {
+	logger *zap.SugaredLogger
+	writer *kafka.Writer
+}
+
+// NewKafkaOutput returns an Output publishing to a Kafka topic.
+func NewKafkaOutput(logger *zap.SugaredLogger, config *KafkaOutputConfig) (Output, error) {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(config.Brokers...),
+		Topic:    config.Topic,
+		Balancer: &kafka.Hash{},
+	}
+
+	return &kafkaOutput{
+		logger: logger.Named("output.kafka"),
+		writer: writer,
+	}, nil
+}
+
+func (o *kafkaOutput) Name() string {
+	return "kafka"
+}
+
+func (o *kafkaOutput) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return o.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Contract),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: "dedupe-id", Value: []byte(event.DedupeID())},
+		},
+	})
+}
+
+func (o *kafkaOutput) HealthCheck(ctx context.Context) error {
+	conn, err := kafka.DialContext(ctx, "tcp", o.writer.Addr.String())
+	if err != nil {
+		return fmt.Errorf("kafka: %w", err)
+	}
+	defer conn.Close()
+	return nil
+}
+
+// Close flushes any messages still buffered by the writer and stops its
+// internal goroutines, as kafka.Writer's own docs require.
+func (o *kafkaOutput) Close(ctx context.Context) error {
+	if err := o.writer.Close(); err != nil {
+		return fmt.Errorf("kafka: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const eventQueueSize = 256
+
+// LogHandler receives decoded events from every Chain and publishes them to
+// the configured Outputs. Events are queued so a Chain does not block on a
+// slow Output until eventQueueSize events are waiting; Run consumes the
+// queue until ctx is cancelled, and Drain
+// flushes whatever is left within a bounded time budget.
+type LogHandler struct {
+	logger  *zap.SugaredLogger
+	outputs *Outputs
+	metrics *Metrics
+	events  chan Event
+}
+
+// NewLogHandler creates a LogHandler publishing to outputs.
+func NewLogHandler(logger *zap.SugaredLogger, outputs *Outputs, metrics *Metrics) *LogHandler {
+	return &LogHandler{
+		logger:  logger,
+		outputs: outputs,
+		metrics: metrics,
+		events:  make(chan Event, eventQueueSize),
+	}
+}
+
+// Handle queues event for asynchronous publishing. If the queue is full, it
+// gives up as soon as ctx is done instead of blocking forever, so a stuck
+// caller can't wedge shutdown once Run has stopped draining the queue.
+func (h *LogHandler) Handle(ctx context.Context, event Event) {
+	select {
+	case h.events <- event:
+		return
+	default:
+	}
+
+	select {
+	case h.events <- event:
+	case <-ctx.Done():
+		h.logger.Warnw("Dropped event on full queue during shutdown", "event", event)
+	}
+}
+
+// Run publishes queued events until ctx is cancelled. Any events still
+// queued at that point are left for Drain.
+func (h *LogHandler) Run(ctx context.Context) error {
+	for {
+		select {
+		case event := <-h.events:
+			h.publish(ctx, event)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Drain flushes the remaining queued events, giving each Publish call up to
+// ctx's deadline. It stops and returns an error as soon as ctx expires,
+// reporting how many events were left unflushed.
+func (h *LogHandler) Drain(ctx context.Context) error {
+	for {
+		select {
+		case event := <-h.events:
+			if err := h.doPublish(ctx, event); err != nil && ctx.Err() != nil {
+				return fmt.Errorf("drain timed out with %d event(s) still queued: %w", len(h.events)+1, ctx.Err())
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (h *LogHandler) publish(ctx context.Context, event Event) {
+	h.doPublish(ctx, event)
+}
+
+// doPublish fans event out to every Output and, on success, records
+// processing metrics; the caller decides how to react to a failure.
+func (h *LogHandler) doPublish(ctx context.Context, event Event) error {
+	if err := h.outputs.Publish(ctx, event); err != nil {
+		h.logger.Errorw("Failed to publish event", "event", event, "err", err)
+		return err
+	}
+
+	if !event.ObservedAt.IsZero() {
+		h.metrics.RecordEvent(event, time.Since(event.ObservedAt))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// slowOutput simulates an Output whose Publish call takes delay to
+// complete, or aborts early if ctx is cancelled first.
+type slowOutput struct {
+	delay    time.Duration
+	received chan Event
+}
+
+func (o *slowOutput) Name() string {
+	return "slow"
+}
+
+func (o *slowOutput) Publish(ctx context.Context, event Event) error {
+	select {
+	case <-time.After(o.delay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	o.received <- event
+	return nil
+}
+
+func (o *slowOutput) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func newTestHandler(output Output) *LogHandler {
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	outputs.Add(output)
+	return NewLogHandler(zap.NewNop().Sugar(), outputs, NewMetrics())
+}
+
+func TestLogHandlerDrainFlushesQueuedEvents(t *testing.T) {
+	output := &slowOutput{delay: 10 * time.Millisecond, received: make(chan Event, 2)}
+	handler := newTestHandler(output)
+
+	handler.Handle(context.Background(), Event{Name: "a"})
+	handler.Handle(context.Background(), Event{Name: "b"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := handler.Drain(ctx); err != nil {
+		t.Fatalf("Drain returned error: %v", err)
+	}
+
+	close(output.received)
+	var names []string
+	for e := range output.received {
+		names = append(names, e.Name)
+	}
+	if len(names) != 2 {
+		t.Fatalf("expected 2 events to be published, got %d: %v", len(names), names)
+	}
+}
+
+func TestLogHandlerHandleReturnsOnFullQueueWhenCtxDone(t *testing.T) {
+	output := &slowOutput{delay: time.Second, received: make(chan Event, eventQueueSize)}
+	handler := newTestHandler(output)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < eventQueueSize; i++ {
+		handler.Handle(ctx, Event{Name: "fill"})
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		handler.Handle(ctx, Event{Name: "overflow"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Handle blocked on a full queue past its cancelled ctx")
+	}
+}
+
+func TestLogHandlerDrainTimesOutOnSlowOutput(t *testing.T) {
+	output := &slowOutput{delay: time.Second, received: make(chan Event, 2)}
+	handler := newTestHandler(output)
+
+	handler.Handle(context.Background(), Event{Name: "a"})
+	handler.Handle(context.Background(), Event{Name: "b"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := handler.Drain(ctx); err == nil {
+		t.Fatal("expected Drain to time out with a slow output")
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// loggerOutput is the default Output: it simply logs every event.
+type loggerOutput struct {
+	logger *zap.SugaredLogger
+}
+
+// NewLoggerOutput creates an Output that logs events via logger.
+func NewLoggerOutput(logger *zap.SugaredLogger) Output {
+	return &loggerOutput{logger: logger.Named("output.console")}
+}
+
+func (o *loggerOutput) Name() string {
+	return "console"
+}
+
+func (o *loggerOutput) Publish(ctx context.Context, event Event) error {
+	o.logger.Infow("Event received",
+		"chain", event.Chain,
+		"contract", event.Contract,
+		"name", event.Name,
+		"txHash", event.TxHash,
+		"logIndex", event.LogIndex,
+		"block", event.BlockNumber,
+		"args", event.Args,
+	)
+	return nil
+}
+
+func (o *loggerOutput) HealthCheck(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds every Prometheus collector the app records against. It owns
+// its own registry rather than the global default, so it can be threaded
+// through AppContext and recorded against by Chains, Outputs and LogHandler
+// without any package-level state.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	eventsProcessed   *prometheus.CounterVec
+	rpcRequests       *prometheus.CounterVec
+	rpcLatency        *prometheus.HistogramVec
+	processingLatency *prometheus.HistogramVec
+	publishErrors     *prometheus.CounterVec
+	headLagBlocks     *prometheus.GaugeVec
+}
+
+// NewMetrics creates a Metrics instance with its own registry.
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: registry,
+		eventsProcessed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "events_processed_total",
+			Help: "Total number of decoded contract events processed.",
+		}, []string{"chain", "contract", "event"}),
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rpc_requests_total",
+			Help: "Total number of RPC requests made to chain endpoints.",
+		}, []string{"chain", "method", "status"}),
+		rpcLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "rpc_request_duration_seconds",
+			Help: "Latency of RPC requests to chain endpoints.",
+		}, []string{"chain", "method"}),
+		processingLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "event_processing_duration_seconds",
+			Help: "End-to-end time from a Chain observing an event to it being published to every Output.",
+		}, []string{"chain", "contract", "event"}),
+		publishErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "output_publish_errors_total",
+			Help: "Total number of Output.Publish calls that still failed after exhausting retries.",
+		}, []string{"sink"}),
+		headLagBlocks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "chain_head_lag_blocks",
+			Help: "Number of blocks the monitor is currently behind the chain's RPC head.",
+		}, []string{"chain"}),
+	}
+
+	registry.MustRegister(
+		m.eventsProcessed,
+		m.rpcRequests,
+		m.rpcLatency,
+		m.processingLatency,
+		m.publishErrors,
+		m.headLagBlocks,
+	)
+
+	return m
+}
+
+// Handler serves this Metrics' collectors in the Prometheus exposition
+// format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RecordEvent records a successfully processed event, along with the
+// end-to-end latency since it was observed.
+func (m *Metrics) RecordEvent(event Event, latency time.Duration) {
+	m.eventsProcessed.WithLabelValues(event.Chain, event.Contract, event.Name).Inc()
+	m.processingLatency.WithLabelValues(event.Chain, event.Contract, event.Name).Observe(latency.Seconds())
+}
+
+// RecordRPCRequest records a single RPC call made against a chain's
+// endpoint.
+func (m *Metrics) RecordRPCRequest(chain, method, status string, latency time.Duration) {
+	m.rpcRequests.WithLabelValues(chain, method, status).Inc()
+	m.rpcLatency.WithLabelValues(chain, method).Observe(latency.Seconds())
+}
+
+// RecordPublishError records an Output that failed to publish an event
+// after exhausting its retries.
+func (m *Metrics) RecordPublishError(sink string) {
+	m.publishErrors.WithLabelValues(sink).Inc()
+}
+
+// SetHeadLag records how many blocks chain is currently behind its RPC
+// head.
+func (m *Metrics) SetHeadLag(chain string, lag float64) {
+	m.headLagBlocks.WithLabelValues(chain).Set(lag)
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsHandlerServesRecordedCounters(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.RecordEvent(Event{Chain: "eth", Contract: "token", Name: "Transfer"}, 10*time.Millisecond)
+	metrics.RecordPublishError("kafka")
+	metrics.SetHeadLag("eth", 3)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`events_processed_total{chain="eth",contract="token",event="Transfer"} 1`,
+		`output_publish_errors_total{sink="kafka"} 1`,
+		`chain_head_lag_blocks{chain="eth"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected /metrics output to contain %q, got:\n%s", want, body)
+		}
+	}
+}
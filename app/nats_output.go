@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const defaultNATSSubjectPrefix = "contracts"
+
+// natsOutput publishes events to NATS JetStream, one subject per
+// chain/contract/event, with a Nats-Msg-Id header so a consumer restart
+// cannot double-process the same message.
+type natsOutput struct {
+	logger        *zap.SugaredLogger
+	nc            *nats.Conn
+	js            nats.JetStreamContext
+	subjectPrefix string
+}
+
+// NewNATSOutput connects to NATS and returns an Output publishing to
+// JetStream.
+func NewNATSOutput(logger *zap.SugaredLogger, config *NATSOutputConfig) (Output, error) {
+	nc, err := nats.Connect(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := nc.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JetStream context: %w", err)
+	}
+
+	prefix := config.SubjectPrefix
+	if prefix == "" {
+		prefix = defaultNATSSubjectPrefix
+	}
+
+	return &natsOutput{
+		logger:        logger.Named("output.nats"),
+		nc:            nc,
+		js:            js,
+		subjectPrefix: prefix,
+	}, nil
+}
+
+func (o *natsOutput) Name() string {
+	return "nats"
+}
+
+func (o *natsOutput) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s.%s.%s.%s", o.subjectPrefix, event.Chain, event.Contract, event.Name)
+	msg := nats.NewMsg(subject)
+	msg.Header.Set(nats.MsgIdHdr, event.DedupeID())
+	msg.Data = data
+
+	_, err = o.js.PublishMsg(msg, nats.Context(ctx))
+	return err
+}
+
+func (o *natsOutput) HealthCheck(ctx context.Context) error {
+	if _, err := o.js.AccountInfo(); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
+
+// Close drains and closes the underlying NATS connection.
+func (o *natsOutput) Close(ctx context.Context) error {
+	return o.nc.Drain()
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxPublishAttempts = 3
+	publishBaseBackoff = 100 * time.Millisecond
+)
+
+// Output is a sink that decoded events are published to, e.g. a logger, a
+// database or a message broker.
+type Output interface {
+	Name() string
+	Publish(ctx context.Context, event Event) error
+	HealthCheck(ctx context.Context) error
+}
+
+// ClosableOutput is optionally implemented by an Output holding a resource
+// that needs releasing on shutdown, e.g. kafkaOutput's batching writer or
+// natsOutput's connection, mirroring ClosableCheckpointStore's pattern.
+// Outputs without one, like the logger output, don't implement it.
+type ClosableOutput interface {
+	Close(ctx context.Context) error
+}
+
+// Outputs fans an event out to every registered Output concurrently, so a
+// failing sink's retries do not delay delivery of that event to the others.
+// Each sink gets its own bounded retry with backoff. Publish waits for every
+// sink, so the next event is not published until the slowest sink is done.
+type Outputs struct {
+	logger  *zap.SugaredLogger
+	metrics *Metrics
+	outputs []Output
+}
+
+// NewOutputs creates an empty Outputs fan-out.
+func NewOutputs(logger *zap.SugaredLogger, metrics *Metrics) *Outputs {
+	return &Outputs{logger: logger, metrics: metrics}
+}
+
+// Add registers an additional Output.
+func (o *Outputs) Add(output Output) {
+	o.outputs = append(o.outputs, output)
+}
+
+// Checks adapts every registered Output to a Check, for registration with
+// the HTTP server's health/ready endpoints.
+func (o *Outputs) Checks() []Check {
+	checks := make([]Check, len(o.outputs))
+	for i, output := range o.outputs {
+		checks[i] = outputCheck{output}
+	}
+	return checks
+}
+
+// Close closes every registered Output that implements ClosableOutput, so
+// e.g. Kafka's batching writer flushes pending messages and NATS's
+// connection is released instead of leaking on shutdown. Sinks without a
+// Close, like the logger output, are skipped. Returns the combined error of
+// any sink that failed to close.
+func (o *Outputs) Close(ctx context.Context) error {
+	var errs []error
+	for _, output := range o.outputs {
+		closable, ok := output.(ClosableOutput)
+		if !ok {
+			continue
+		}
+		if err := closable.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("output %s: %w", output.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Publish fans event out to every registered Output concurrently and waits
+// for them all, returning the combined error of any sinks that still failed
+// after retrying.
+func (o *Outputs) Publish(ctx context.Context, event Event) error {
+	if len(o.outputs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(o.outputs))
+
+	var wg sync.WaitGroup
+	for i, output := range o.outputs {
+		i, output := i, output
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := publishWithRetry(ctx, o.logger, output, event); err != nil {
+				o.metrics.RecordPublishError(output.Name())
+				errs[i] = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// publishWithRetry calls output.Publish, retrying with exponential backoff
+// up to maxPublishAttempts before giving up.
+func publishWithRetry(ctx context.Context, logger *zap.SugaredLogger, output Output, event Event) error {
+	var err error
+	for attempt := 1; attempt <= maxPublishAttempts; attempt++ {
+		if err = output.Publish(ctx, event); err == nil {
+			return nil
+		}
+
+		logger.Warnw("Output publish failed", "output", output.Name(), "attempt", attempt, "err", err)
+
+		if attempt == maxPublishAttempts {
+			break
+		}
+
+		backoff := publishBaseBackoff * time.Duration(1<<(attempt-1))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("output %s: %w", output.Name(), err)
+}
+
+// outputCheck adapts an Output to the Check interface expected by Server. A
+// sink is either up or it isn't, so the same HealthCheck backs both
+// liveness and readiness, unlike a Chain which can be live but not yet
+// caught up.
+type outputCheck struct {
+	output Output
+}
+
+func (c outputCheck) Name() string {
+	return c.output.Name()
+}
+
+func (c outputCheck) CheckLive(ctx context.Context) error {
+	return c.output.HealthCheck(ctx)
+}
+
+func (c outputCheck) CheckReady(ctx context.Context) error {
+	return c.output.HealthCheck(ctx)
+}
+
+// NewConfiguredOutputs builds every Output enabled in config.Outputs except
+// Postgres, which app.Run wires up separately since it also owns schema
+// migration for the chains. Adding a new sink only requires a case here,
+// not a change to app.Run.
+func NewConfiguredOutputs(config *Config, logger *zap.SugaredLogger, metrics *Metrics) (*Outputs, error) {
+	outputs := NewOutputs(logger.Named("outputs"), metrics)
+
+	if config.Outputs.Console == nil || !config.Outputs.Console.Disabled {
+		outputs.Add(NewLoggerOutput(logger))
+	}
+
+	if config.Outputs.NATS != nil {
+		output, err := NewNATSOutput(logger, config.Outputs.NATS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure NATS output: %w", err)
+		}
+		outputs.Add(output)
+	}
+
+	if config.Outputs.Kafka != nil {
+		output, err := NewKafkaOutput(logger, config.Outputs.Kafka)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure Kafka output: %w", err)
+		}
+		outputs.Add(output)
+	}
+
+	return outputs, nil
+}
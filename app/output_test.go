@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type countingOutput struct {
+	name      string
+	failUntil int32
+	calls     int32
+}
+
+func (o *countingOutput) Name() string { return o.name }
+
+func (o *countingOutput) Publish(ctx context.Context, event Event) error {
+	n := atomic.AddInt32(&o.calls, 1)
+	if n <= o.failUntil {
+		return errors.New("transient failure")
+	}
+	return nil
+}
+
+func (o *countingOutput) HealthCheck(ctx context.Context) error { return nil }
+
+func TestOutputsPublishFansOutAndRetriesPerSink(t *testing.T) {
+	good := &countingOutput{name: "good"}
+	flaky := &countingOutput{name: "flaky", failUntil: 1}
+
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	outputs.Add(good)
+	outputs.Add(flaky)
+
+	if err := outputs.Publish(context.Background(), Event{Name: "test"}); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+
+	if good.calls != 1 {
+		t.Errorf("expected good output to be called once, got %d", good.calls)
+	}
+	if flaky.calls != 2 {
+		t.Errorf("expected flaky output to be retried once after failing, got %d calls", flaky.calls)
+	}
+}
+
+// closableOutput is a countingOutput that also implements ClosableOutput,
+// for tests exercising Outputs.Close.
+type closableOutput struct {
+	countingOutput
+	closed   int32
+	closeErr error
+}
+
+func (o *closableOutput) Close(ctx context.Context) error {
+	atomic.AddInt32(&o.closed, 1)
+	return o.closeErr
+}
+
+func TestOutputsCloseClosesOnlyClosableSinks(t *testing.T) {
+	closable := &closableOutput{countingOutput: countingOutput{name: "kafka"}}
+	plain := &countingOutput{name: "console"}
+
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	outputs.Add(closable)
+	outputs.Add(plain)
+
+	if err := outputs.Close(context.Background()); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if closable.closed != 1 {
+		t.Errorf("expected closable sink to be closed once, got %d", closable.closed)
+	}
+}
+
+func TestOutputsCloseReturnsSinkErrors(t *testing.T) {
+	failing := &closableOutput{countingOutput: countingOutput{name: "kafka"}, closeErr: errors.New("flush failed")}
+
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	outputs.Add(failing)
+
+	if err := outputs.Close(context.Background()); err == nil {
+		t.Fatal("expected Close to return the sink's error")
+	}
+}
+
+func TestOutputsPublishReturnsErrorAfterExhaustingRetries(t *testing.T) {
+	alwaysFails := &countingOutput{name: "broken", failUntil: maxPublishAttempts}
+
+	outputs := NewOutputs(zap.NewNop().Sugar(), NewMetrics())
+	outputs.Add(alwaysFails)
+
+	if err := outputs.Publish(context.Background(), Event{Name: "test"}); err == nil {
+		t.Fatal("expected Publish to return an error once retries are exhausted")
+	}
+}
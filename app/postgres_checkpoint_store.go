@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"sync"
+)
+
+// postgresCheckpointStore is NOT actually Postgres-backed: Database has no
+// live connection pool in this tree (Connect/Publish are no-ops, see
+// database.go), so there is nothing for this store to query or write to.
+// It falls back to an in-memory map, which means checkpoints picked as
+// "Postgres-backed" do NOT survive a process restart, even though that's
+// the one guarantee this whole request exists to provide. Do not rely on
+// this for restart durability until Database grows a real driver; until
+// then, NewBoltCheckpointStore is the only backend that actually persists
+// across restarts.
+type postgresCheckpointStore struct {
+	db *Database
+
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+// NewPostgresCheckpointStore returns a CheckpointStore that claims to be
+// backed by db but is really just an in-memory map guarded by a mutex; see
+// the type's doc comment for why, and use it only where that is acceptable.
+func NewPostgresCheckpointStore(db *Database) CheckpointStore {
+	db.logger.Warnw("Postgres checkpoint store is not actually persistent in this build; checkpoints will not survive a restart")
+	return &postgresCheckpointStore{db: db, checkpoints: make(map[string]Checkpoint)}
+}
+
+// LoadCheckpoint reads the last acknowledged block for chain, returning the
+// zero Checkpoint if none has been saved yet. This never touches Postgres;
+// see the type's doc comment.
+func (s *postgresCheckpointStore) LoadCheckpoint(ctx context.Context, chain string) (Checkpoint, error) {
+	s.db.logger.Debugw("Loading checkpoint", "chain", chain)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[chain], nil
+}
+
+// SaveCheckpoint records checkpoint as the last acknowledged block for
+// chain, in memory only; see the type's doc comment.
+func (s *postgresCheckpointStore) SaveCheckpoint(ctx context.Context, chain string, checkpoint Checkpoint) error {
+	s.db.logger.Debugw("Saving checkpoint", "chain", chain, "block", checkpoint.BlockNumber, "hash", checkpoint.BlockHash)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[chain] = checkpoint
+	return nil
+}
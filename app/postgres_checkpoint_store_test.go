@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// TestPostgresCheckpointStoreRoundTripsWithinProcess only proves that a
+// saved checkpoint is readable back in the same process; it says nothing
+// about surviving a restart, because postgresCheckpointStore is really just
+// an in-memory map (see its doc comment) until Database gets a real
+// connection.
+func TestPostgresCheckpointStoreRoundTripsWithinProcess(t *testing.T) {
+	store := NewPostgresCheckpointStore(NewDatabase(zap.NewNop().Sugar()))
+	ctx := context.Background()
+
+	checkpoint, err := store.LoadCheckpoint(ctx, "eth")
+	if err != nil {
+		t.Fatalf("LoadCheckpoint returned error: %v", err)
+	}
+	if checkpoint != (Checkpoint{}) {
+		t.Fatalf("expected zero Checkpoint for unseen chain, got %+v", checkpoint)
+	}
+
+	want := Checkpoint{BlockNumber: 42, BlockHash: "0xabc"}
+	if err := store.SaveCheckpoint(ctx, "eth", want); err != nil {
+		t.Fatalf("SaveCheckpoint returned error: %v", err)
+	}
+
+	got, err := store.LoadCheckpoint(ctx, "eth")
+	if err != nil {
+		t.Fatalf("LoadCheckpoint returned error: %v", err)
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
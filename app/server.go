@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Check is implemented by anything the HTTP server can probe, e.g. a Chain
+// reporting RPC lag or an Output reporting connectivity.
+type Check interface {
+	Name() string
+	CheckLive(ctx context.Context) error
+	CheckReady(ctx context.Context) error
+}
+
+// Server is the app's HTTP server: health/readiness probes, Prometheus
+// metrics and, if enabled, pprof.
+type Server struct {
+	logger  *zap.SugaredLogger
+	config  *Config
+	ctx     context.Context
+	metrics *Metrics
+
+	mu                sync.Mutex
+	checks            []Check
+	readyOnlyChecks   []Check
+	checkpointSources []CheckpointSource
+}
+
+// NewServer creates the app's HTTP server.
+func NewServer(a AppContext) *Server {
+	return &Server{
+		logger:  a.Logger("server"),
+		config:  a.Config(),
+		ctx:     a.Context(),
+		metrics: a.Metrics(),
+	}
+}
+
+// Register adds check to the set of probes backing both /api/health and
+// /api/ready, e.g. a Chain whose RPC connection failing is a hard error.
+func (s *Server) Register(check Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks = append(s.checks, check)
+}
+
+// RegisterReadyOnly adds check to the set of probes backing only
+// /api/ready, e.g. an Output whose outage shouldn't trigger a pod restart
+// that can't fix it.
+func (s *Server) RegisterReadyOnly(check Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readyOnlyChecks = append(s.readyOnlyChecks, check)
+}
+
+// RegisterCheckpointSource adds source to the set reported by
+// /api/checkpoints.
+func (s *Server) RegisterCheckpointSource(source CheckpointSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpointSources = append(s.checkpointSources, source)
+}
+
+// Run starts the HTTP server and blocks until it is shut down.
+func (s *Server) Run() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/health", s.handleHealth)
+	mux.HandleFunc("/api/ready", s.handleReady)
+	mux.HandleFunc("/api/checkpoints", s.handleCheckpoints)
+	mux.Handle("/metrics", s.metrics.Handler())
+
+	if s.config.HTTP.EnablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	srv := &http.Server{
+		Addr:    s.config.HTTP.ListenAddr,
+		Handler: mux,
+	}
+
+	go func() {
+		<-s.ctx.Done()
+		s.logger.Infow("Shutting down HTTP server", "cause", context.Cause(s.ctx))
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeoutOrDefault())
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	s.logger.Infow("HTTP server listening", "addr", s.config.HTTP.ListenAddr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+type checkStatus struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Status string        `json:"status"`
+	Checks []checkStatus `json:"checks"`
+}
+
+// handleHealth is the liveness probe: it only fails on hard process-level
+// errors (e.g. a chain's RPC connection), never on a downstream Output
+// outage, since restarting the pod can't fix a dead sink and shouldn't be
+// triggered by one.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	checks := make([]Check, len(s.checks))
+	copy(checks, s.checks)
+	s.mu.Unlock()
+
+	s.writeStatus(w, r, checks, Check.CheckLive)
+}
+
+// handleReady is the readiness probe: it fails until every chain has caught
+// up within its freshness window, and also reports Output outages so
+// traffic/consumers can be held back until sinks recover.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	checks := make([]Check, 0, len(s.checks)+len(s.readyOnlyChecks))
+	checks = append(checks, s.checks...)
+	checks = append(checks, s.readyOnlyChecks...)
+	s.mu.Unlock()
+
+	s.writeStatus(w, r, checks, Check.CheckReady)
+}
+
+func (s *Server) writeStatus(w http.ResponseWriter, r *http.Request, checks []Check, probe func(Check, context.Context) error) {
+
+	resp := healthResponse{Status: "ok"}
+	for _, c := range checks {
+		status := checkStatus{Name: c.Name()}
+		if err := probe(c, r.Context()); err != nil {
+			status.Error = err.Error()
+			resp.Status = "unavailable"
+		}
+		resp.Checks = append(resp.Checks, status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+type checkpointResponse struct {
+	Name        string `json:"name"`
+	BlockNumber uint64 `json:"block_number"`
+	BlockHash   string `json:"block_hash,omitempty"`
+}
+
+// handleCheckpoints reports each chain's last acknowledged block, useful
+// for confirming a restart resumed where it left off.
+func (s *Server) handleCheckpoints(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	sources := make([]CheckpointSource, len(s.checkpointSources))
+	copy(sources, s.checkpointSources)
+	s.mu.Unlock()
+
+	resp := make([]checkpointResponse, 0, len(sources))
+	for _, source := range sources {
+		checkpoint := source.Checkpoint()
+		resp = append(resp, checkpointResponse{
+			Name:        source.Name(),
+			BlockNumber: checkpoint.BlockNumber,
+			BlockHash:   checkpoint.BlockHash,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type fakeCheck struct {
+	name     string
+	liveErr  error
+	readyErr error
+}
+
+func (c fakeCheck) Name() string                         { return c.name }
+func (c fakeCheck) CheckLive(ctx context.Context) error  { return c.liveErr }
+func (c fakeCheck) CheckReady(ctx context.Context) error { return c.readyErr }
+
+func newTestServer() *Server {
+	return &Server{
+		logger:  zap.NewNop().Sugar(),
+		config:  &Config{},
+		ctx:     context.Background(),
+		metrics: NewMetrics(),
+	}
+}
+
+func TestServerReadyOnlyCheckDoesNotFailHealth(t *testing.T) {
+	s := newTestServer()
+	s.RegisterReadyOnly(fakeCheck{name: "postgres", readyErr: context.DeadlineExceeded})
+
+	healthRec := httptest.NewRecorder()
+	s.handleHealth(healthRec, httptest.NewRequest("GET", "/api/health", nil))
+	if healthRec.Code != 200 {
+		t.Errorf("expected /api/health to stay up on an Output outage, got %d", healthRec.Code)
+	}
+
+	readyRec := httptest.NewRecorder()
+	s.handleReady(readyRec, httptest.NewRequest("GET", "/api/ready", nil))
+	if readyRec.Code != 503 {
+		t.Errorf("expected /api/ready to fail on an Output outage, got %d", readyRec.Code)
+	}
+}
+
+func TestServerRegisteredCheckFailsBothHealthAndReady(t *testing.T) {
+	s := newTestServer()
+	s.Register(fakeCheck{name: "chain", liveErr: context.DeadlineExceeded, readyErr: context.DeadlineExceeded})
+
+	healthRec := httptest.NewRecorder()
+	s.handleHealth(healthRec, httptest.NewRequest("GET", "/api/health", nil))
+	if healthRec.Code != 503 {
+		t.Errorf("expected /api/health to fail on a hard chain error, got %d", healthRec.Code)
+	}
+
+	readyRec := httptest.NewRecorder()
+	s.handleReady(readyRec, httptest.NewRequest("GET", "/api/ready", nil))
+	if readyRec.Code != 503 {
+		t.Errorf("expected /api/ready to fail on a hard chain error, got %d", readyRec.Code)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ErrShuttingDown is the cancellation cause passed to gctx when
+// SIGINT/SIGTERM is received, letting subsystems tell a planned shutdown
+// apart from an RPC-induced failure cancelling the same context.
+var ErrShuttingDown = errors.New("application is shutting down")
+
+// ShutdownHandler blocks until SIGINT or SIGTERM is received, then invokes
+// cancel with ErrShuttingDown.
+func ShutdownHandler(cancel context.CancelCauseFunc) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	cancel(ErrShuttingDown)
+}